@@ -3,9 +3,12 @@ package go_http_client
 import (
 	"errors"
 	"fmt"
+	"net"
 	"net/http"
+	"net/http/cookiejar"
 	"net/url"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -26,22 +29,66 @@ type HttpClient interface {
 	DefaultHeaders() http.Header
 	WithDefaultQueries(map[string][]string) HttpClient
 	DefaultQueries() url.Values
+	RetryPolicy(*RetryPolicy) HttpClient
+	GetRetryPolicy() *RetryPolicy
+	RegisterCodec(contentType string, enc Encoder, dec Decoder) HttpClient
+	GetEncoder(contentType string) (Encoder, bool)
+	GetDecoder(contentType string) (Decoder, bool)
+	WithDecompression(bool) HttpClient
+	GetDecompression() bool
+	MaxResponseBytes(int64) HttpClient
+	GetMaxResponseBytes() int64
+	Use(middlewares ...Middleware) HttpClient
+	GetMiddlewares() []Middleware
+	WithCookieJar(http.CookieJar) HttpClient
+	GetCookieJar() http.CookieJar
+	GetHTTPClient(timeout time.Duration) *http.Client
 	NewHttpRequest() HttpRequest
 }
 
+type codecPair struct {
+	enc Encoder
+	dec Decoder
+}
+
+// httpClientCacheKey identifies a reusable *http.Client by timeout+jar.
+// The resolved transport deliberately isn't part of the key: its concrete
+// type is always roundTripFunc, which wraps a func value and is therefore
+// unhashable, so comparing/hashing it as a map key panics at runtime. The
+// transport only changes via Transport/Use, which invalidate the cache
+// wholesale instead (see resolvedTransport).
+type httpClientCacheKey struct {
+	timeout time.Duration
+	jar     http.CookieJar
+}
+
 type httpClient struct {
-	transport      http.RoundTripper
-	host           string
-	urlPrefix      string
-	maxRedirects   int
-	timeout        time.Duration
-	userAgent      string
-	defaultHeaders http.Header
-	defaultQueries url.Values
+	transport        http.RoundTripper
+	host             string
+	urlPrefix        string
+	maxRedirects     int
+	timeout          time.Duration
+	userAgent        string
+	defaultHeaders   http.Header
+	defaultQueries   url.Values
+	retryPolicy      *RetryPolicy
+	codecs           map[string]*codecPair
+	decompression    bool
+	maxResponseBytes int64
+	middlewares      []Middleware
+	jar              http.CookieJar
+
+	effectiveTransport http.RoundTripper
+	clientCacheMu      sync.Mutex
+	clientCache        map[httpClientCacheKey]*http.Client
 }
 
 func (c *httpClient) Transport(transport http.RoundTripper) HttpClient {
+	c.clientCacheMu.Lock()
 	c.transport = transport
+	c.effectiveTransport = nil
+	c.clientCache = nil
+	c.clientCacheMu.Unlock()
 
 	return c
 }
@@ -130,25 +177,189 @@ func (c *httpClient) DefaultQueries() url.Values {
 	return c.defaultQueries
 }
 
+func (c *httpClient) RetryPolicy(policy *RetryPolicy) HttpClient {
+	c.retryPolicy = policy
+
+	return c
+}
+
+func (c *httpClient) GetRetryPolicy() *RetryPolicy {
+	return c.retryPolicy
+}
+
+func (c *httpClient) RegisterCodec(contentType string, enc Encoder, dec Decoder) HttpClient {
+	c.codecs[baseContentType(contentType)] = &codecPair{enc: enc, dec: dec}
+
+	return c
+}
+
+func (c *httpClient) GetEncoder(contentType string) (Encoder, bool) {
+	pair, ok := c.codecs[baseContentType(contentType)]
+	if !ok || pair.enc == nil {
+		return nil, false
+	}
+
+	return pair.enc, true
+}
+
+func (c *httpClient) GetDecoder(contentType string) (Decoder, bool) {
+	pair, ok := c.codecs[baseContentType(contentType)]
+	if !ok || pair.dec == nil {
+		return nil, false
+	}
+
+	return pair.dec, true
+}
+
+func (c *httpClient) WithDecompression(enabled bool) HttpClient {
+	c.decompression = enabled
+
+	return c
+}
+
+func (c *httpClient) GetDecompression() bool {
+	return c.decompression
+}
+
+func (c *httpClient) MaxResponseBytes(max int64) HttpClient {
+	c.maxResponseBytes = max
+
+	return c
+}
+
+func (c *httpClient) GetMaxResponseBytes() int64 {
+	return c.maxResponseBytes
+}
+
+func (c *httpClient) Use(middlewares ...Middleware) HttpClient {
+	c.clientCacheMu.Lock()
+	c.middlewares = append(c.middlewares, middlewares...)
+	c.effectiveTransport = nil
+	c.clientCache = nil
+	c.clientCacheMu.Unlock()
+
+	return c
+}
+
+func (c *httpClient) GetMiddlewares() []Middleware {
+	return c.middlewares
+}
+
+func (c *httpClient) WithCookieJar(jar http.CookieJar) HttpClient {
+	c.jar = jar
+
+	return c
+}
+
+func (c *httpClient) GetCookieJar() http.CookieJar {
+	return c.jar
+}
+
+// resolvedTransport builds (once, until invalidated by Transport/Use) the
+// http.RoundTripper that wraps the configured transport with the registered
+// middleware chain. Callers must hold c.clientCacheMu: it reads/writes
+// c.effectiveTransport alongside c.transport/c.middlewares, all of which can
+// be mutated concurrently by Transport/Use while other goroutines Submit.
+func (c *httpClient) resolvedTransport() http.RoundTripper {
+	if c.effectiveTransport != nil {
+		return c.effectiveTransport
+	}
+
+	base := c.transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	roundTrip := RoundTrip(base.RoundTrip)
+	if len(c.middlewares) > 0 {
+		roundTrip = composeMiddlewares(roundTrip, c.middlewares)
+	}
+
+	c.effectiveTransport = roundTripFunc{fn: roundTrip}
+
+	return c.effectiveTransport
+}
+
+// GetHTTPClient returns a *http.Client for the given timeout, reusing a
+// previously built one keyed by timeout+jar instead of allocating on every
+// request.
+func (c *httpClient) GetHTTPClient(timeout time.Duration) *http.Client {
+	c.clientCacheMu.Lock()
+	defer c.clientCacheMu.Unlock()
+
+	transport := c.resolvedTransport()
+	key := httpClientCacheKey{timeout: timeout, jar: c.jar}
+
+	if c.clientCache == nil {
+		c.clientCache = make(map[httpClientCacheKey]*http.Client)
+	}
+
+	if cached, ok := c.clientCache[key]; ok {
+		return cached
+	}
+
+	client := &http.Client{
+		Transport:     transport,
+		Timeout:       timeout,
+		Jar:           c.jar,
+		CheckRedirect: checkRedirect,
+	}
+
+	c.clientCache[key] = client
+
+	return client
+}
+
 func (c *httpClient) NewHttpRequest() HttpRequest {
 	return NewHttpRequest(c)
 }
 
+// defaultTransport builds a *http.Transport wired to the connection-level
+// timeouts on config, used whenever config.Transport is not set explicitly.
+func defaultTransport(config *ClientConfig) http.RoundTripper {
+	dialer := &net.Dialer{Timeout: config.DialTimeout}
+
+	return &http.Transport{
+		DialContext:           dialer.DialContext,
+		TLSHandshakeTimeout:   config.TLSHandshakeTimeout,
+		ResponseHeaderTimeout: config.ResponseHeaderTimeout,
+		IdleConnTimeout:       config.IdleConnTimeout,
+	}
+}
+
 func NewHttpClient(config *ClientConfig) (HttpClient, error) {
 	if nil == config {
 		return nil, errors.New("config is nil")
 	}
 
-	return &httpClient{
-		transport:      config.Transport,
-		host:           config.Host,
-		urlPrefix:      config.UrlPrefix,
-		maxRedirects:   config.MaxRedirects,
-		timeout:        config.Timeout,
-		userAgent:      config.UserAgent,
-		defaultHeaders: make(http.Header),
-		defaultQueries: make(url.Values),
-	}, nil
+	transport := config.Transport
+	if transport == nil {
+		transport = defaultTransport(config)
+	}
+
+	client := &httpClient{
+		transport:        transport,
+		host:             config.Host,
+		urlPrefix:        config.UrlPrefix,
+		maxRedirects:     config.MaxRedirects,
+		timeout:          config.Timeout,
+		userAgent:        config.UserAgent,
+		defaultHeaders:   make(http.Header),
+		defaultQueries:   make(url.Values),
+		retryPolicy:      config.RetryPolicy,
+		codecs:           make(map[string]*codecPair),
+		decompression:    config.Decompression,
+		maxResponseBytes: config.MaxResponseBytes,
+	}
+
+	client.RegisterCodec(ContentTypeJSON, jsonCodec{}, jsonCodec{})
+	client.RegisterCodec(ContentTypeXML, xmlCodec{}, xmlCodec{})
+	client.RegisterCodec(ContentTypeForm, formCodec{}, formCodec{})
+
+	jar, _ := cookiejar.New(nil)
+	client.jar = jar
+
+	return client, nil
 }
 
 func HttpClientFor(host string, prefixes ...string) (HttpClient, error) {
@@ -0,0 +1,67 @@
+package go_http_client
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestSubmitDoesNotPanicOnCachedClient is a regression test for a cache key
+// built from the resolved transport, whose concrete type (roundTripFunc)
+// wraps a func value and is unhashable, panicking on the very first Submit.
+func TestSubmitDoesNotPanicOnCachedClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client, err := HttpClientFor(server.URL)
+	if err != nil {
+		t.Fatalf("HttpClientFor: %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		resp, err := client.NewHttpRequest().Get("/")
+		if err != nil {
+			t.Fatalf("Get: %v", err)
+		}
+
+		if !resp.IsSuccess() {
+			t.Fatalf("expected success, got status %d", resp.StatusCode())
+		}
+	}
+}
+
+// TestSubmitPersistsCookiesAcrossRequests checks that the client's cookie
+// jar carries a Set-Cookie from one request into the next request's Cookie
+// header.
+func TestSubmitPersistsCookiesAcrossRequests(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if cookie, err := r.Cookie("session"); err == nil {
+			w.Header().Set("X-Saw-Cookie", cookie.Value)
+		} else {
+			http.SetCookie(w, &http.Cookie{Name: "session", Value: "abc123"})
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client, err := HttpClientFor(server.URL)
+	if err != nil {
+		t.Fatalf("HttpClientFor: %v", err)
+	}
+
+	if _, err := client.NewHttpRequest().Get("/"); err != nil {
+		t.Fatalf("first Get: %v", err)
+	}
+
+	resp, err := client.NewHttpRequest().Get("/")
+	if err != nil {
+		t.Fatalf("second Get: %v", err)
+	}
+
+	if got, _ := resp.GetHeader("x-saw-cookie"); got != "abc123" {
+		t.Fatalf("expected server to see cookie %q, got %q", "abc123", got)
+	}
+}
@@ -0,0 +1,159 @@
+package go_http_client
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+const (
+	ContentTypeJSON = "application/json"
+	ContentTypeXML  = "application/xml"
+	ContentTypeForm = "application/x-www-form-urlencoded"
+)
+
+// Encoder marshals a value into a request body for a given Content-Type.
+type Encoder interface {
+	ContentType() string
+	Encode(v interface{}) ([]byte, error)
+}
+
+// Decoder unmarshals a response body produced with a matching Content-Type.
+type Decoder interface {
+	ContentType() string
+	Decode(data []byte, dest interface{}) error
+}
+
+var encodeBufferPool = sync.Pool{
+	New: func() interface{} {
+		return new(bytes.Buffer)
+	},
+}
+
+// baseContentType strips parameters (e.g. "; charset=utf-8") from a
+// Content-Type header value for codec lookup and comparisons.
+func baseContentType(contentType string) string {
+	if idx := strings.IndexByte(contentType, ';'); idx >= 0 {
+		contentType = contentType[:idx]
+	}
+
+	return strings.TrimSpace(strings.ToLower(contentType))
+}
+
+type jsonCodec struct{}
+
+func (jsonCodec) ContentType() string {
+	return ContentTypeJSON
+}
+
+func (jsonCodec) Encode(v interface{}) ([]byte, error) {
+	buf := encodeBufferPool.Get().(*bytes.Buffer)
+	buf.Reset()
+	defer encodeBufferPool.Put(buf)
+
+	if err := json.NewEncoder(buf).Encode(v); err != nil {
+		return nil, err
+	}
+
+	out := make([]byte, buf.Len())
+	copy(out, buf.Bytes())
+
+	return out, nil
+}
+
+func (jsonCodec) Decode(data []byte, dest interface{}) error {
+	return json.Unmarshal(data, dest)
+}
+
+type xmlCodec struct{}
+
+func (xmlCodec) ContentType() string {
+	return ContentTypeXML
+}
+
+func (xmlCodec) Encode(v interface{}) ([]byte, error) {
+	buf := encodeBufferPool.Get().(*bytes.Buffer)
+	buf.Reset()
+	defer encodeBufferPool.Put(buf)
+
+	if err := xml.NewEncoder(buf).Encode(v); err != nil {
+		return nil, err
+	}
+
+	out := make([]byte, buf.Len())
+	copy(out, buf.Bytes())
+
+	return out, nil
+}
+
+func (xmlCodec) Decode(data []byte, dest interface{}) error {
+	return xml.Unmarshal(data, dest)
+}
+
+type formCodec struct{}
+
+func (formCodec) ContentType() string {
+	return ContentTypeForm
+}
+
+func (formCodec) Encode(v interface{}) ([]byte, error) {
+	values, err := toURLValues(v)
+	if err != nil {
+		return nil, err
+	}
+
+	return []byte(values.Encode()), nil
+}
+
+func (formCodec) Decode(data []byte, dest interface{}) error {
+	return fmt.Errorf("go-http-client: form decoding is not supported")
+}
+
+// toURLValues converts url.Values, map[string]string, map[string][]string,
+// or a struct tagged with `form:"name"` into url.Values.
+func toURLValues(v interface{}) (url.Values, error) {
+	switch t := v.(type) {
+	case url.Values:
+		return t, nil
+	case map[string][]string:
+		return url.Values(t), nil
+	case map[string]string:
+		values := make(url.Values, len(t))
+		for k, val := range t {
+			values.Set(k, val)
+		}
+
+		return values, nil
+	}
+
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr {
+		rv = rv.Elem()
+	}
+
+	if rv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("go-http-client: cannot encode %T as form data", v)
+	}
+
+	values := make(url.Values)
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		name := field.Tag.Get("form")
+		if name == "" {
+			name = field.Name
+		}
+		if name == "-" {
+			continue
+		}
+
+		values.Set(name, fmt.Sprintf("%v", rv.Field(i).Interface()))
+	}
+
+	return values, nil
+}
@@ -0,0 +1,73 @@
+package go_http_client
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type codecTestPayload struct {
+	Name string `json:"name" xml:"name"`
+}
+
+// TestSubmitJSONRoundTrip checks that HttpRequest.JSON encodes the request
+// body using the registered JSON codec and HttpResponse.ParseAs decodes the
+// JSON response.
+func TestSubmitJSONRoundTrip(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Fatalf("read body: %v", err)
+		}
+
+		w.Header().Set("Content-Type", ContentTypeJSON)
+		w.Write(body)
+	}))
+	defer server.Close()
+
+	client, err := HttpClientFor(server.URL)
+	if err != nil {
+		t.Fatalf("HttpClientFor: %v", err)
+	}
+
+	resp, err := client.NewHttpRequest().JSON(codecTestPayload{Name: "anik"}).Post("/")
+	if err != nil {
+		t.Fatalf("Post: %v", err)
+	}
+
+	var out codecTestPayload
+	if err := resp.ParseAs(&out); err != nil {
+		t.Fatalf("ParseAs: %v", err)
+	}
+
+	if out.Name != "anik" {
+		t.Fatalf("expected name %q, got %q", "anik", out.Name)
+	}
+}
+
+// TestSubmitFormEncoding checks that HttpRequest.Form encodes the request
+// body as application/x-www-form-urlencoded.
+func TestSubmitFormEncoding(t *testing.T) {
+	var gotBody string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		gotBody = string(body)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client, err := HttpClientFor(server.URL)
+	if err != nil {
+		t.Fatalf("HttpClientFor: %v", err)
+	}
+
+	_, err = client.NewHttpRequest().Form(map[string]string{"name": "anik"}).Post("/")
+	if err != nil {
+		t.Fatalf("Post: %v", err)
+	}
+
+	if gotBody != "name=anik" {
+		t.Fatalf("expected encoded form body %q, got %q", "name=anik", gotBody)
+	}
+}
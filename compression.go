@@ -0,0 +1,65 @@
+package go_http_client
+
+import (
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// multiCloser closes every underlying closer, used to close both a
+// decompressor and the original response body it wraps.
+type multiCloser struct {
+	io.Reader
+	closers []io.Closer
+}
+
+func (m *multiCloser) Close() error {
+	var err error
+	for _, c := range m.closers {
+		if cerr := c.Close(); cerr != nil && err == nil {
+			err = cerr
+		}
+	}
+
+	return err
+}
+
+// limitedReadCloser applies io.LimitReader while preserving Close on the
+// underlying reader.
+type limitedReadCloser struct {
+	io.Reader
+	closer io.Closer
+}
+
+func (l *limitedReadCloser) Close() error {
+	return l.closer.Close()
+}
+
+// wrapDecompression transparently decompresses the response body based on
+// its Content-Encoding header when decompress is true.
+func wrapDecompression(response *http.Response, decompress bool) (io.ReadCloser, bool, error) {
+	if !decompress {
+		return response.Body, false, nil
+	}
+
+	switch strings.ToLower(strings.TrimSpace(response.Header.Get("Content-Encoding"))) {
+	case "gzip":
+		gz, err := gzip.NewReader(response.Body)
+		if err != nil {
+			return nil, false, err
+		}
+
+		return &multiCloser{Reader: gz, closers: []io.Closer{gz, response.Body}}, true, nil
+	case "deflate":
+		zr, err := zlib.NewReader(response.Body)
+		if err != nil {
+			return nil, false, err
+		}
+
+		return &multiCloser{Reader: zr, closers: []io.Closer{zr, response.Body}}, true, nil
+	default:
+		return response.Body, false, nil
+	}
+}
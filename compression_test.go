@@ -0,0 +1,67 @@
+package go_http_client
+
+import (
+	"bytes"
+	"compress/gzip"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestSubmitDecompressesGzipResponse checks that a gzip-encoded response is
+// transparently decompressed when the client has decompression enabled.
+func TestSubmitDecompressesGzipResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var buf bytes.Buffer
+		gz := gzip.NewWriter(&buf)
+		gz.Write([]byte("hello world"))
+		gz.Close()
+
+		w.Header().Set("Content-Encoding", "gzip")
+		w.Write(buf.Bytes())
+	}))
+	defer server.Close()
+
+	client, err := HttpClientFor(server.URL)
+	if err != nil {
+		t.Fatalf("HttpClientFor: %v", err)
+	}
+	client.WithDecompression(true)
+
+	resp, err := client.NewHttpRequest().Get("/")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+
+	if got := string(resp.Content()); got != "hello world" {
+		t.Fatalf("expected decompressed body %q, got %q", "hello world", got)
+	}
+
+	if _, ok := resp.GetHeader("content-encoding"); ok {
+		t.Fatal("expected content-encoding header to be stripped after decompression")
+	}
+}
+
+// TestSubmitEnforcesMaxResponseBytes checks that MaxResponseBytes caps how
+// much of the response body is read.
+func TestSubmitEnforcesMaxResponseBytes(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("hello world"))
+	}))
+	defer server.Close()
+
+	client, err := HttpClientFor(server.URL)
+	if err != nil {
+		t.Fatalf("HttpClientFor: %v", err)
+	}
+	client.MaxResponseBytes(5)
+
+	resp, err := client.NewHttpRequest().Get("/")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+
+	if got := string(resp.Content()); got != "hello" {
+		t.Fatalf("expected body truncated to %q, got %q", "hello", got)
+	}
+}
@@ -11,19 +11,33 @@ var (
 )
 
 type ClientConfig struct {
-	Transport    http.RoundTripper
-	Host         string
-	UrlPrefix    string
-	MaxRedirects int
-	Timeout      time.Duration
-	UserAgent    string
+	Transport             http.RoundTripper
+	Host                  string
+	UrlPrefix             string
+	MaxRedirects          int
+	Timeout               time.Duration
+	UserAgent             string
+	RetryPolicy           *RetryPolicy
+	Decompression         bool
+	MaxResponseBytes      int64
+	DialTimeout           time.Duration
+	TLSHandshakeTimeout   time.Duration
+	ResponseHeaderTimeout time.Duration
+	IdleConnTimeout       time.Duration
 }
 
 var DefaultClientConfig = &ClientConfig{
-	Transport:    nil,
-	Host:         "",
-	UrlPrefix:    "",
-	MaxRedirects: 10,
-	Timeout:      60 * time.Second,
-	UserAgent:    defaultUserAgent,
+	Transport:             nil,
+	Host:                  "",
+	UrlPrefix:             "",
+	MaxRedirects:          10,
+	Timeout:               60 * time.Second,
+	UserAgent:             defaultUserAgent,
+	RetryPolicy:           nil,
+	Decompression:         false,
+	MaxResponseBytes:      0,
+	DialTimeout:           30 * time.Second,
+	TLSHandshakeTimeout:   10 * time.Second,
+	ResponseHeaderTimeout: 0,
+	IdleConnTimeout:       90 * time.Second,
 }
@@ -0,0 +1,51 @@
+package go_http_client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+)
+
+// TooManyRedirects is returned by CheckRedirect once a request has exceeded
+// HttpClient/HttpRequest's configured MaxRedirects.
+var TooManyRedirects = errors.New("go-http-client: too many redirects")
+
+// TimeoutError wraps a transport failure caused by a timeout (dial, TLS
+// handshake, response header, overall request, or a request Deadline/
+// context), letting callers detect it via the Timeout() bool method instead
+// of matching on error strings.
+type TimeoutError struct {
+	err error
+}
+
+func (e *TimeoutError) Error() string {
+	return fmt.Sprintf("go-http-client: request timed out: %v", e.err)
+}
+
+func (e *TimeoutError) Timeout() bool {
+	return true
+}
+
+func (e *TimeoutError) Unwrap() error {
+	return e.err
+}
+
+// wrapTimeoutError wraps err in a *TimeoutError when it represents a
+// timeout, otherwise it returns err unchanged.
+func wrapTimeoutError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return &TimeoutError{err: err}
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) {
+		return &TimeoutError{err: err}
+	}
+
+	return err
+}
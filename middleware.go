@@ -0,0 +1,191 @@
+package go_http_client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httputil"
+	"sync"
+	"time"
+)
+
+// RoundTrip performs a single HTTP round trip, mirroring
+// http.RoundTripper.RoundTrip as a plain function type so middlewares can be
+// composed without implementing an interface.
+type RoundTrip func(*http.Request) (*http.Response, error)
+
+// Middleware wraps a RoundTrip with cross-cutting behavior (logging,
+// tracing, metrics, auth, ...), similar to net/http's RoundTripper
+// composition and resty's OnBeforeRequest/OnAfterResponse hooks.
+type Middleware func(next RoundTrip) RoundTrip
+
+type roundTripFunc struct {
+	fn RoundTrip
+}
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f.fn(req)
+}
+
+// composeMiddlewares builds the final RoundTrip by wrapping base with each
+// middleware, in the order they were registered via HttpClient.Use, so the
+// first-registered middleware is the outermost and runs first on the way
+// out and last on the way back.
+func composeMiddlewares(base RoundTrip, middlewares []Middleware) RoundTrip {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		base = middlewares[i](base)
+	}
+
+	return base
+}
+
+// LoggingMiddleware dumps each outgoing request curl-style (via
+// httputil.DumpRequestOut) before it is sent.
+func LoggingMiddleware(logf func(string)) Middleware {
+	return func(next RoundTrip) RoundTrip {
+		return func(req *http.Request) (*http.Response, error) {
+			if dump, err := httputil.DumpRequestOut(req, true); err == nil {
+				logf(string(dump))
+			}
+
+			return next(req)
+		}
+	}
+}
+
+// Tracer starts a span for req and returns the context carrying it along
+// with a function to end the span once the round trip completes. It is
+// intentionally minimal so callers can plug in OpenTelemetry or any other
+// tracer without this package depending on it.
+type Tracer func(ctx context.Context, req *http.Request) (context.Context, func(resp *http.Response, err error))
+
+// TracingMiddleware starts and ends a span, keyed by req.Context(), around
+// every round trip.
+func TracingMiddleware(tracer Tracer) Middleware {
+	return func(next RoundTrip) RoundTrip {
+		return func(req *http.Request) (*http.Response, error) {
+			ctx, end := tracer(req.Context(), req)
+			req = req.WithContext(ctx)
+
+			resp, err := next(req)
+			end(resp, err)
+
+			return resp, err
+		}
+	}
+}
+
+// Metrics accumulates Prometheus-style counters for requests routed through
+// MetricsMiddleware: a total count, a per-status-code count, and observed
+// latencies.
+type Metrics struct {
+	mu          sync.Mutex
+	count       int64
+	statusCount map[int]int64
+	latencies   []time.Duration
+}
+
+func NewMetrics() *Metrics {
+	return &Metrics{statusCount: make(map[int]int64)}
+}
+
+func (m *Metrics) Count() int64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.count
+}
+
+func (m *Metrics) StatusCount(status int) int64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.statusCount[status]
+}
+
+func (m *Metrics) Latencies() []time.Duration {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	out := make([]time.Duration, len(m.latencies))
+	copy(out, m.latencies)
+
+	return out
+}
+
+func (m *Metrics) observe(status int, d time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.count++
+	m.statusCount[status]++
+	m.latencies = append(m.latencies, d)
+}
+
+// MetricsMiddleware records a count/latency/status histogram for every
+// round trip into metrics.
+func MetricsMiddleware(metrics *Metrics) Middleware {
+	return func(next RoundTrip) RoundTrip {
+		return func(req *http.Request) (*http.Response, error) {
+			start := time.Now()
+			resp, err := next(req)
+
+			status := 0
+			if resp != nil {
+				status = resp.StatusCode
+			}
+
+			metrics.observe(status, time.Since(start))
+
+			return resp, err
+		}
+	}
+}
+
+// BasicAuthMiddleware attaches HTTP Basic auth credentials to every request.
+func BasicAuthMiddleware(user, pass string) Middleware {
+	return func(next RoundTrip) RoundTrip {
+		return func(req *http.Request) (*http.Response, error) {
+			req.SetBasicAuth(user, pass)
+
+			return next(req)
+		}
+	}
+}
+
+// BearerTokenMiddleware attaches a bearer token obtained from tokenFunc,
+// fetching it lazily on first use and re-fetching it after a 401 response.
+func BearerTokenMiddleware(tokenFunc func(ctx context.Context) (string, error)) Middleware {
+	var mu sync.Mutex
+	var cached string
+
+	return func(next RoundTrip) RoundTrip {
+		return func(req *http.Request) (*http.Response, error) {
+			mu.Lock()
+			token := cached
+			mu.Unlock()
+
+			if token == "" {
+				t, err := tokenFunc(req.Context())
+				if err != nil {
+					return nil, err
+				}
+
+				token = t
+				mu.Lock()
+				cached = t
+				mu.Unlock()
+			}
+
+			req.Header.Set("Authorization", "Bearer "+token)
+
+			resp, err := next(req)
+			if resp != nil && resp.StatusCode == http.StatusUnauthorized {
+				mu.Lock()
+				cached = ""
+				mu.Unlock()
+			}
+
+			return resp, err
+		}
+	}
+}
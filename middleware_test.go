@@ -0,0 +1,43 @@
+package go_http_client
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestUseComposesMiddlewaresInRegistrationOrder checks that middlewares
+// registered via Use run outermost-first on the way out, mirroring the
+// order they were registered in.
+func TestUseComposesMiddlewaresInRegistrationOrder(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client, err := HttpClientFor(server.URL)
+	if err != nil {
+		t.Fatalf("HttpClientFor: %v", err)
+	}
+
+	var order []string
+	mark := func(name string) Middleware {
+		return func(next RoundTrip) RoundTrip {
+			return func(req *http.Request) (*http.Response, error) {
+				order = append(order, name)
+
+				return next(req)
+			}
+		}
+	}
+
+	client.Use(mark("first"), mark("second"))
+
+	if _, err := client.NewHttpRequest().Get("/"); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+
+	if len(order) != 2 || order[0] != "first" || order[1] != "second" {
+		t.Fatalf("expected [first second], got %v", order)
+	}
+}
@@ -0,0 +1,138 @@
+package go_http_client
+
+import (
+	"io"
+	"mime/multipart"
+	"os"
+	"path/filepath"
+)
+
+// MultipartBuilder assembles a multipart/form-data request body. Fields and
+// files are streamed through an io.Pipe when the request is sent, so large
+// files are never buffered in memory.
+type MultipartBuilder interface {
+	AddField(name, value string) MultipartBuilder
+	// AddFile attaches r as a file part. r is consumed once, so a request
+	// carrying an AddFile part is never retried (see httpRequest.forceNonReplayable);
+	// use AddFilePath when the retry subsystem needs to replay the upload.
+	AddFile(name, filename string, r io.Reader) MultipartBuilder
+	AddFilePath(name, path string) MultipartBuilder
+}
+
+type multipartPart interface {
+	write(w *multipart.Writer) error
+}
+
+type multipartField struct {
+	name  string
+	value string
+}
+
+func (f multipartField) write(w *multipart.Writer) error {
+	return w.WriteField(f.name, f.value)
+}
+
+type multipartFile struct {
+	name     string
+	filename string
+	reader   io.Reader
+}
+
+func (f multipartFile) write(w *multipart.Writer) error {
+	part, err := w.CreateFormFile(f.name, f.filename)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(part, f.reader)
+
+	return err
+}
+
+type multipartFilePath struct {
+	name string
+	path string
+}
+
+func (f multipartFilePath) write(w *multipart.Writer) error {
+	file, err := os.Open(f.path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	part, err := w.CreateFormFile(f.name, filepath.Base(f.path))
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(part, file)
+
+	return err
+}
+
+type multipartBuilder struct {
+	request  *httpRequest
+	boundary string
+	parts    []multipartPart
+}
+
+func newMultipartBuilder(r *httpRequest) *multipartBuilder {
+	boundary := multipart.NewWriter(io.Discard).Boundary()
+
+	b := &multipartBuilder{
+		request:  r,
+		boundary: boundary,
+	}
+
+	r.BodyStream(b.stream)
+	r.AddHeader("Content-Type", "multipart/form-data; boundary="+boundary)
+
+	return b
+}
+
+func (b *multipartBuilder) AddField(name, value string) MultipartBuilder {
+	b.parts = append(b.parts, multipartField{name: name, value: value})
+
+	return b
+}
+
+func (b *multipartBuilder) AddFile(name, filename string, r io.Reader) MultipartBuilder {
+	b.parts = append(b.parts, multipartFile{name: name, filename: filename, reader: r})
+	b.request.forceNonReplayable = true
+
+	return b
+}
+
+func (b *multipartBuilder) AddFilePath(name, path string) MultipartBuilder {
+	b.parts = append(b.parts, multipartFilePath{name: name, path: path})
+
+	return b
+}
+
+// stream builds the multipart body on demand, writing each part into an
+// io.Pipe on a separate goroutine so the writer side never needs to buffer
+// the whole body.
+func (b *multipartBuilder) stream() (io.ReadCloser, error) {
+	pr, pw := io.Pipe()
+	mw := multipart.NewWriter(pw)
+	if err := mw.SetBoundary(b.boundary); err != nil {
+		return nil, err
+	}
+
+	go func() {
+		var err error
+		for _, part := range b.parts {
+			if err = part.write(mw); err != nil {
+				break
+			}
+		}
+		if err == nil {
+			err = mw.Close()
+		}
+
+		pw.CloseWithError(err)
+	}()
+
+	return pr, nil
+}
@@ -0,0 +1,74 @@
+package go_http_client
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// TestSubmitMultipartBody checks that MultipartBuilder streams fields and
+// files into a single multipart/form-data request body.
+func TestSubmitMultipartBody(t *testing.T) {
+	var gotField, gotFile string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			t.Fatalf("ParseMultipartForm: %v", err)
+		}
+
+		gotField = r.FormValue("title")
+
+		file, _, err := r.FormFile("upload")
+		if err != nil {
+			t.Fatalf("FormFile: %v", err)
+		}
+		defer file.Close()
+
+		buf := make([]byte, 32)
+		n, _ := file.Read(buf)
+		gotFile = string(buf[:n])
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client, err := HttpClientFor(server.URL)
+	if err != nil {
+		t.Fatalf("HttpClientFor: %v", err)
+	}
+
+	req := client.NewHttpRequest()
+	req.Multipart().
+		AddField("title", "hello").
+		AddFile("upload", "upload.txt", strings.NewReader("hello world"))
+
+	resp, err := req.Post("/")
+	if err != nil {
+		t.Fatalf("Post: %v", err)
+	}
+
+	if !resp.IsSuccess() {
+		t.Fatalf("expected success, got status %d", resp.StatusCode())
+	}
+
+	if gotField != "hello" || gotFile != "hello world" {
+		t.Fatalf("expected field %q and file %q, got %q and %q", "hello", "hello world", gotField, gotFile)
+	}
+}
+
+// TestMultipartAddFileDisablesRetry checks that a multipart body built from
+// a raw io.Reader is never retried, since the reader can't be replayed.
+func TestMultipartAddFileDisablesRetry(t *testing.T) {
+	client, err := HttpClientFor("http://example.invalid")
+	if err != nil {
+		t.Fatalf("HttpClientFor: %v", err)
+	}
+
+	r := client.NewHttpRequest().(*httpRequest)
+	r.Method(http.MethodPost)
+	r.Multipart().AddFile("upload", "upload.txt", strings.NewReader("hello world"))
+
+	if r.replayableBody() {
+		t.Fatal("expected replayableBody to be false after AddFile")
+	}
+}
@@ -4,26 +4,55 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
 	"strings"
 	"time"
 )
 
+// maxRedirectsContextKey carries the effective max-redirect count for a
+// single request through its context, so a cached, shared *http.Client can
+// enforce it statelessly via checkRedirect instead of a per-request closure.
+type maxRedirectsContextKey struct{}
+
+func checkRedirect(req *http.Request, via []*http.Request) error {
+	maxRedirects, _ := req.Context().Value(maxRedirectsContextKey{}).(int)
+	if len(via) > maxRedirects {
+		return TooManyRedirects
+	}
+
+	return nil
+}
+
 type HttpRequest interface {
 	WithContext(context.Context) HttpRequest
 	UserAgent(ua string) HttpRequest
 	MaxRedirects(int) HttpRequest
 	NoRedirect() HttpRequest
 	Timeout(time.Duration) HttpRequest
+	Deadline(time.Time) HttpRequest
 	Headers(http.Header) HttpRequest
 	AddHeader(k string, v string) HttpRequest
 	SkipDefaultHeaders() HttpRequest
 	Queries(url.Values) HttpRequest
 	SkipDefaultQueries() HttpRequest
 	Body([]byte) HttpRequest
+	BodyReader(io.Reader) HttpRequest
+	BodyStream(func() (io.ReadCloser, error)) HttpRequest
+	Multipart() MultipartBuilder
+	JSON(v interface{}) HttpRequest
+	XML(v interface{}) HttpRequest
+	Form(v interface{}) HttpRequest
+	AcceptEncoding(string) HttpRequest
+	Host(string) HttpRequest
+	AddCookie(*http.Cookie) HttpRequest
+	BasicAuth(user, pass string) HttpRequest
+	BearerAuth(token string) HttpRequest
 	Method(string) HttpRequest
 	Path(string) HttpRequest
+	Retry(count int, opts ...RetryOption) HttpRequest
+	RetryIf(RetryCondition) HttpRequest
 	Send(method string, path string, body []byte, headers http.Header) (HttpResponse, error)
 	Get(path ...string) (HttpResponse, error)
 	Post(path ...string) (HttpResponse, error)
@@ -46,6 +75,18 @@ type httpRequest struct {
 	skipDefaultHeaders bool
 	method             string
 	path               string
+	retryPolicy        *RetryPolicy
+	bodyErr            error
+	bodyReader         io.Reader
+	bodyStream         func() (io.ReadCloser, error)
+	acceptEncoding     *string
+	hostOverride       *string
+	cookies            []*http.Cookie
+	basicAuthUser      *string
+	basicAuthPass      string
+	bearerToken        *string
+	deadline           *time.Time
+	forceNonReplayable bool
 }
 
 func (r *httpRequest) WithContext(ctx context.Context) HttpRequest {
@@ -85,6 +126,15 @@ func (r *httpRequest) NoTimeout() HttpRequest {
 	return r
 }
 
+// Deadline derives a context.WithDeadline from the request's current
+// context (or context.Background if none was supplied via WithContext) and
+// applies it on Submit, analogous to net.Conn.SetDeadline.
+func (r *httpRequest) Deadline(deadline time.Time) HttpRequest {
+	r.deadline = &deadline
+
+	return r
+}
+
 func (r *httpRequest) Headers(headers http.Header) HttpRequest {
 	r.headers = headers
 
@@ -117,6 +167,139 @@ func (r *httpRequest) SkipDefaultQueries() HttpRequest {
 
 func (r *httpRequest) Body(b []byte) HttpRequest {
 	r.body = b
+	r.bodyReader = nil
+	r.bodyStream = nil
+
+	return r
+}
+
+// BodyReader sets the request body to an arbitrary io.Reader. Unlike Body
+// and BodyStream, the reader is consumed once and is not replayable by the
+// retry subsystem.
+func (r *httpRequest) BodyReader(reader io.Reader) HttpRequest {
+	r.bodyReader = reader
+	r.body = nil
+	r.bodyStream = nil
+
+	return r
+}
+
+// BodyStream sets a factory that is invoked once per attempt to obtain a
+// fresh request body, allowing the retry subsystem to re-send it.
+func (r *httpRequest) BodyStream(stream func() (io.ReadCloser, error)) HttpRequest {
+	r.bodyStream = stream
+	r.body = nil
+	r.bodyReader = nil
+
+	return r
+}
+
+func (r *httpRequest) Multipart() MultipartBuilder {
+	return newMultipartBuilder(r)
+}
+
+// AcceptEncoding sets the outgoing Accept-Encoding header and enables
+// transparent decompression of the response for this request, regardless
+// of the client's WithDecompression setting.
+func (r *httpRequest) AcceptEncoding(encodings string) HttpRequest {
+	r.acceptEncoding = &encodings
+
+	return r
+}
+
+// Host sets the outgoing request's Host field, overriding the target host
+// for virtual-host routing without changing the dialed address.
+func (r *httpRequest) Host(host string) HttpRequest {
+	r.hostOverride = &host
+
+	return r
+}
+
+func (r *httpRequest) AddCookie(cookie *http.Cookie) HttpRequest {
+	r.cookies = append(r.cookies, cookie)
+
+	return r
+}
+
+func (r *httpRequest) BasicAuth(user, pass string) HttpRequest {
+	r.basicAuthUser = &user
+	r.basicAuthPass = pass
+
+	return r
+}
+
+func (r *httpRequest) BearerAuth(token string) HttpRequest {
+	r.bearerToken = &token
+
+	return r
+}
+
+// hasBody reports whether any body has been attached to the request.
+func (r *httpRequest) hasBody() bool {
+	return r.body != nil || r.bodyReader != nil || r.bodyStream != nil
+}
+
+// replayableBody reports whether the attached body (if any) can be
+// re-obtained for a retried attempt. A multipart body built from a raw
+// io.Reader (MultipartBuilder.AddFile) sets forceNonReplayable, since that
+// reader is consumed on the first attempt and would silently send an empty
+// part on a retry.
+func (r *httpRequest) replayableBody() bool {
+	if r.forceNonReplayable {
+		return false
+	}
+
+	return r.body != nil || r.bodyStream != nil
+}
+
+// bodyForAttempt returns the reader to use for a single attempt.
+func (r *httpRequest) bodyForAttempt() (io.Reader, error) {
+	switch {
+	case r.bodyStream != nil:
+		rc, err := r.bodyStream()
+		if err != nil {
+			return nil, err
+		}
+
+		return rc, nil
+	case r.bodyReader != nil:
+		return r.bodyReader, nil
+	case r.body != nil:
+		return bytes.NewReader(r.body), nil
+	default:
+		return nil, nil
+	}
+}
+
+func (r *httpRequest) JSON(v interface{}) HttpRequest {
+	return r.encodeBody(ContentTypeJSON, v)
+}
+
+func (r *httpRequest) XML(v interface{}) HttpRequest {
+	return r.encodeBody(ContentTypeXML, v)
+}
+
+func (r *httpRequest) Form(v interface{}) HttpRequest {
+	return r.encodeBody(ContentTypeForm, v)
+}
+
+func (r *httpRequest) encodeBody(contentType string, v interface{}) HttpRequest {
+	enc, ok := r.client.GetEncoder(contentType)
+	if !ok {
+		r.bodyErr = fmt.Errorf("go-http-client: no encoder registered for %s", contentType)
+
+		return r
+	}
+
+	data, err := enc.Encode(v)
+	if err != nil {
+		r.bodyErr = err
+
+		return r
+	}
+
+	r.Body(data)
+	r.AddHeader("Content-Type", contentType)
 
 	return r
 }
@@ -133,6 +316,29 @@ func (r *httpRequest) Method(m string) HttpRequest {
 	return r
 }
 
+func (r *httpRequest) Retry(count int, opts ...RetryOption) HttpRequest {
+	r.retryPolicy = newRetryPolicy(count, opts...)
+
+	return r
+}
+
+// RetryIf overrides the retry condition without disturbing the rest of the
+// policy. It always clones the policy before mutating it: r.retryPolicy may
+// still be the client's shared *RetryPolicy (seeded by NewHttpRequest from
+// client.GetRetryPolicy()), and mutating that in place would leak this
+// request's condition into every other request built from the same client.
+func (r *httpRequest) RetryIf(cond RetryCondition) HttpRequest {
+	if r.retryPolicy == nil {
+		r.retryPolicy = newRetryPolicy(0)
+	} else {
+		p := *r.retryPolicy
+		r.retryPolicy = &p
+	}
+	r.retryPolicy.Condition = cond
+
+	return r
+}
+
 func (r *httpRequest) Get(path ...string) (HttpResponse, error) {
 	r.Method(http.MethodGet)
 
@@ -193,6 +399,10 @@ func (r *httpRequest) Send(method string, path string, body []byte, headers http
 }
 
 func (r *httpRequest) Submit() (HttpResponse, error) {
+	if r.bodyErr != nil {
+		return nil, r.bodyErr
+	}
+
 	host := r.client.GetHost()
 	prefix := r.client.GetUrlPrefix()
 
@@ -233,21 +443,20 @@ func (r *httpRequest) Submit() (HttpResponse, error) {
 
 	url := fmt.Sprintf("%s%s%s%s", host, prefix, path, qp)
 
-	req, err := http.NewRequest(r.method, url, bytes.NewBuffer(r.body))
-	if err != nil {
-		return nil, err
-	}
-
-	if r.ctx != nil {
-		req = req.WithContext(r.ctx)
-	}
-
 	if r.userAgent != nil {
 		r.AddHeader("User-Agent", *r.userAgent)
 	} else if ua := r.client.GetUserAgent(); ua != "" {
 		r.AddHeader("User-Agent", ua)
 	}
 
+	decompress := r.client.GetDecompression()
+	if r.acceptEncoding != nil {
+		decompress = true
+		r.AddHeader("Accept-Encoding", *r.acceptEncoding)
+	} else if decompress {
+		r.AddHeader("Accept-Encoding", "gzip, deflate")
+	}
+
 	headers := r.headers
 	if !r.skipDefaultHeaders {
 		for k, v := range r.client.DefaultHeaders() {
@@ -257,12 +466,6 @@ func (r *httpRequest) Submit() (HttpResponse, error) {
 		}
 	}
 
-	for k, v := range headers {
-		for _, each := range v {
-			req.Header.Add(k, each)
-		}
-	}
-
 	timeout := r.client.GetTimeout()
 	if r.timeout != nil {
 		timeout = *r.timeout
@@ -273,32 +476,115 @@ func (r *httpRequest) Submit() (HttpResponse, error) {
 		maxRedirects = *r.maxRedirects
 	}
 
-	client := &http.Client{
-		Transport: r.client.GetTransport(),
-		Timeout:   timeout,
-		CheckRedirect: func(req *http.Request, via []*http.Request) error {
-			if maxRedirects <= 0 {
-				return TooManyRedirects
+	client := r.client.GetHTTPClient(timeout)
+
+	policy := r.retryPolicy
+	if policy == nil {
+		policy = newRetryPolicy(0)
+	}
+
+	condition := policy.Condition
+	if condition == nil {
+		condition = defaultRetryCondition(r.method, r.hasBody() && !r.replayableBody())
+	}
+
+	maxAttempts := policy.Count
+	if r.hasBody() && !r.replayableBody() {
+		maxAttempts = 0
+	}
+
+	// ctx is composed once for the whole Submit call (user ctx, if any, plus
+	// Deadline) so both the per-attempt request and the inter-retry sleep
+	// observe the same cancellation, instead of only ever watching r.ctx.
+	ctx := r.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if r.deadline != nil {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithDeadline(ctx, *r.deadline)
+		defer cancel()
+	}
+
+	var response *http.Response
+	var doErr error
+
+	for attempt := 0; ; attempt++ {
+		select {
+		case <-ctx.Done():
+			return nil, wrapTimeoutError(ctx.Err())
+		default:
+		}
+
+		body, err := r.bodyForAttempt()
+		if err != nil {
+			return nil, err
+		}
+
+		req, err := http.NewRequest(r.method, url, body)
+		if err != nil {
+			return nil, err
+		}
+
+		req = req.WithContext(context.WithValue(ctx, maxRedirectsContextKey{}, maxRedirects))
+
+		if r.hostOverride != nil {
+			req.Host = *r.hostOverride
+		}
+
+		for _, cookie := range r.cookies {
+			req.AddCookie(cookie)
+		}
+
+		if r.basicAuthUser != nil {
+			req.SetBasicAuth(*r.basicAuthUser, r.basicAuthPass)
+		}
+
+		if r.bearerToken != nil {
+			req.Header.Set("Authorization", "Bearer "+*r.bearerToken)
+		}
+
+		for k, v := range headers {
+			for _, each := range v {
+				req.Header.Add(k, each)
 			}
+		}
+
+		response, doErr = client.Do(req)
+		if attempt >= maxAttempts || !condition(response, doErr) {
+			break
+		}
+
+		wait := retryWait(policy, attempt, response)
+		if policy.OnRetry != nil {
+			policy.OnRetry(attempt+1, response, doErr)
+		}
 
-			maxRedirects--
+		if response != nil {
+			response.Body.Close()
+		}
 
-			return nil
-		},
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, wrapTimeoutError(ctx.Err())
+		case <-timer.C:
+		}
 	}
 
-	response, err := client.Do(req)
-	if err != nil {
-		return nil, err
+	if doErr != nil {
+		return nil, wrapTimeoutError(doErr)
 	}
 
-	return newHttpResponse(response)
+	return newHttpResponse(response, r.client, decompress)
 }
 
 func NewHttpRequest(client HttpClient) HttpRequest {
 	return &httpRequest{
-		client:  client,
-		headers: make(http.Header),
-		queries: make(url.Values),
+		client:      client,
+		headers:     make(http.Header),
+		queries:     make(url.Values),
+		retryPolicy: client.GetRetryPolicy(),
 	}
 }
@@ -0,0 +1,69 @@
+package go_http_client
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// TestSubmitDeadlineReturnsTimeoutError checks that a Deadline which expires
+// before a slow handler responds surfaces a *TimeoutError rather than a bare
+// context error.
+func TestSubmitDeadlineReturnsTimeoutError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client, err := HttpClientFor(server.URL)
+	if err != nil {
+		t.Fatalf("HttpClientFor: %v", err)
+	}
+
+	_, err = client.NewHttpRequest().Deadline(time.Now().Add(10 * time.Millisecond)).Get("/")
+	if err == nil {
+		t.Fatal("expected a timeout error, got nil")
+	}
+
+	var timeoutErr *TimeoutError
+	if !errors.As(err, &timeoutErr) {
+		t.Fatalf("expected *TimeoutError, got %T: %v", err, err)
+	}
+
+	if !timeoutErr.Timeout() {
+		t.Fatal("expected Timeout() to report true")
+	}
+}
+
+// TestSubmitDeadlineBailsOutDuringRetryBackoff checks that Deadline is
+// observed by the inter-retry sleep, not just the in-flight request, even
+// when no context was supplied via WithContext.
+func TestSubmitDeadlineBailsOutDuringRetryBackoff(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	client, err := HttpClientFor(server.URL)
+	if err != nil {
+		t.Fatalf("HttpClientFor: %v", err)
+	}
+
+	start := time.Now()
+	_, err = client.NewHttpRequest().
+		Deadline(time.Now().Add(50*time.Millisecond)).
+		Retry(5, WithBaseWait(time.Second), WithMaxWait(3*time.Second)).
+		Get("/")
+	elapsed := time.Since(start)
+
+	if err == nil {
+		t.Fatal("expected a timeout error, got nil")
+	}
+
+	if elapsed > time.Second {
+		t.Fatalf("expected Deadline to cut the retry backoff short, took %s", elapsed)
+	}
+}
@@ -1,10 +1,13 @@
 package go_http_client
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"net/http"
+	"os"
 	"reflect"
 	"strings"
 )
@@ -20,15 +23,24 @@ type HttpResponse interface {
 	GetHeader(key string) (string, bool)
 	IsJsonResponse() bool
 	Content() []byte
+	Err() error
 	ParseJson() (map[string]interface{}, error)
 	ParseAs(dest interface{}) error
+	Decode(dest interface{}) error
+	Stream() io.ReadCloser
+	Save(path string) error
 }
 
 type httpResponse struct {
 	original   *http.Response
 	statusCode int
 	body       []byte
+	loaded     bool
+	bufferErr  error
+	streamed   bool
+	reader     io.ReadCloser
 	headers    http.Header
+	client     HttpClient
 }
 
 func (r *httpResponse) Original() *http.Response {
@@ -72,22 +84,40 @@ func (r *httpResponse) GetHeader(key string) (string, bool) {
 
 func (r *httpResponse) IsJsonResponse() bool {
 	v, ok := r.GetHeader("content-type")
-	if !ok || v != "application/json" {
+	if !ok {
 		return false
 	}
 
-	return true
+	return baseContentType(v) == ContentTypeJSON
 }
 
+// Content returns the buffered response body, reading it on first call. Its
+// signature predates streaming support and can't return an error, so a
+// failed/truncated read (e.g. the connection drops mid-download) is only
+// reported via Err() — check Err() after Content() if a truncated body
+// must not pass silently.
 func (r *httpResponse) Content() []byte {
+	_ = r.buffer()
+
 	return r.body
 }
 
+// Err reports the error, if any, from the most recent attempt to buffer the
+// response body (via Content, ParseJson, ParseAs or Decode). Stream and Save
+// do not buffer the body and never set it.
+func (r *httpResponse) Err() error {
+	return r.bufferErr
+}
+
 func (r *httpResponse) ParseJson() (map[string]interface{}, error) {
 	if !r.IsJsonResponse() {
 		return nil, errors.New("not a json response")
 	}
 
+	if err := r.buffer(); err != nil {
+		return nil, err
+	}
+
 	var m map[string]interface{}
 	err := json.Unmarshal(r.body, &m)
 
@@ -99,10 +129,6 @@ func (r *httpResponse) ParseAs(dest interface{}) error {
 		return errors.New("dest is nil")
 	}
 
-	if len(r.body) == 0 {
-		return errors.New("body is empty")
-	}
-
 	if reflect.ValueOf(dest).Kind() != reflect.Ptr {
 		return errors.New("dest is not a pointer")
 	}
@@ -111,31 +137,126 @@ func (r *httpResponse) ParseAs(dest interface{}) error {
 		return errors.New("not a json response")
 	}
 
-	if _, ok := dest.(json.Unmarshaler); !ok {
-		return errors.New("dest does not implement json.Unmarshaler")
+	if err := r.buffer(); err != nil {
+		return err
+	}
+
+	if len(r.body) == 0 {
+		return errors.New("body is empty")
 	}
 
 	return json.Unmarshal(r.body, dest)
 }
 
-func newHttpResponse(response *http.Response) (HttpResponse, error) {
-	statusCode := response.StatusCode
+// Decode unmarshals the response body into dest using the Decoder
+// registered on the client for the response's Content-Type.
+func (r *httpResponse) Decode(dest interface{}) error {
+	if nil == dest {
+		return errors.New("dest is nil")
+	}
+
+	contentType, ok := r.GetHeader("content-type")
+	if !ok {
+		return errors.New("response has no content-type header")
+	}
+
+	dec, ok := r.client.GetDecoder(contentType)
+	if !ok {
+		return fmt.Errorf("go-http-client: no decoder registered for %s", contentType)
+	}
+
+	if err := r.buffer(); err != nil {
+		return err
+	}
+
+	return dec.Decode(r.body, dest)
+}
+
+// Stream returns the raw response body for callers that want to consume a
+// large download without buffering it in memory. Once Stream has been
+// called, Content/ParseJson/ParseAs/Decode can no longer read the body; if
+// the body was already buffered, Stream returns a reader over those bytes.
+func (r *httpResponse) Stream() io.ReadCloser {
+	if r.loaded {
+		return io.NopCloser(bytes.NewReader(r.body))
+	}
+
+	r.streamed = true
+
+	return r.reader
+}
+
+// Save streams the response body directly to path without buffering the
+// whole payload in memory.
+func (r *httpResponse) Save(path string) error {
+	stream := r.Stream()
+	defer stream.Close()
 
-	body, err := io.ReadAll(response.Body)
+	file, err := os.Create(path)
 	if err != nil {
-		return nil, err
+		return err
+	}
+	defer file.Close()
+
+	_, err = io.Copy(file, stream)
+
+	return err
+}
+
+// buffer lazily reads the (decompressed, size-limited) response body into
+// memory, memoizing the result so repeated calls are cheap.
+func (r *httpResponse) buffer() error {
+	if r.loaded {
+		return nil
+	}
+
+	if r.bufferErr != nil {
+		return r.bufferErr
+	}
+
+	if r.streamed {
+		return errors.New("go-http-client: response body already streamed")
+	}
+
+	defer r.reader.Close()
+
+	body, err := io.ReadAll(r.reader)
+	if err != nil {
+		r.bufferErr = err
+
+		return err
 	}
-	defer response.Body.Close()
 
+	r.body = body
+	r.loaded = true
+
+	return nil
+}
+
+func newHttpResponse(response *http.Response, client HttpClient, decompress bool) (HttpResponse, error) {
 	headers := make(http.Header)
 	for k, v := range response.Header {
 		headers[strings.ToLower(k)] = v
 	}
 
+	reader, decompressed, err := wrapDecompression(response, decompress)
+	if err != nil {
+		return nil, err
+	}
+
+	if decompressed {
+		delete(headers, "content-encoding")
+	}
+
+	if maxBytes := client.GetMaxResponseBytes(); maxBytes > 0 {
+		reader = &limitedReadCloser{Reader: io.LimitReader(reader, maxBytes), closer: reader}
+	}
+
 	return &httpResponse{
 		original:   response,
-		statusCode: statusCode,
-		body:       body,
+		statusCode: response.StatusCode,
 		headers:    headers,
+		client:     client,
+		reader:     reader,
 	}, nil
 }
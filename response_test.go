@@ -0,0 +1,48 @@
+package go_http_client
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestContentSurfacesTruncatedBodyViaErr is a regression test: Content()
+// can't return an error (its signature predates streaming), so a body read
+// that fails partway through must still be observable via Err() instead of
+// silently yielding a truncated byte slice.
+func TestContentSurfacesTruncatedBodyViaErr(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hj, ok := w.(http.Hijacker)
+		if !ok {
+			t.Fatal("ResponseWriter does not support hijacking")
+		}
+
+		conn, buf, err := hj.Hijack()
+		if err != nil {
+			t.Fatalf("Hijack: %v", err)
+		}
+		defer conn.Close()
+
+		buf.WriteString("HTTP/1.1 200 OK\r\nContent-Length: 11\r\n\r\nabc")
+		buf.Flush()
+		conn.(*net.TCPConn).CloseWrite()
+	}))
+	defer server.Close()
+
+	client, err := HttpClientFor(server.URL)
+	if err != nil {
+		t.Fatalf("HttpClientFor: %v", err)
+	}
+
+	resp, err := client.NewHttpRequest().Get("/")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+
+	_ = resp.Content()
+
+	if resp.Err() == nil {
+		t.Fatal("expected Err() to report the truncated read")
+	}
+}
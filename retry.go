@@ -0,0 +1,167 @@
+package go_http_client
+
+import (
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryCondition decides whether a request should be retried given the
+// response and/or error produced by the previous attempt. Either resp or
+// err may be nil depending on whether the transport round trip succeeded.
+type RetryCondition func(resp *http.Response, err error) bool
+
+// RetryOption configures a RetryPolicy built by HttpRequest.Retry.
+type RetryOption func(*RetryPolicy)
+
+// RetryPolicy controls how HttpRequest.Submit retries a failed attempt.
+// A zero-value policy performs no retries.
+type RetryPolicy struct {
+	Count     int
+	BaseWait  time.Duration
+	MaxWait   time.Duration
+	Condition RetryCondition
+	OnRetry   func(attempt int, resp *http.Response, err error)
+}
+
+var (
+	defaultRetryBaseWait = 100 * time.Millisecond
+	defaultRetryMaxWait  = 2 * time.Second
+)
+
+// WithBaseWait sets the base wait duration used by the exponential backoff.
+func WithBaseWait(d time.Duration) RetryOption {
+	return func(p *RetryPolicy) {
+		p.BaseWait = d
+	}
+}
+
+// WithMaxWait caps the exponential backoff between attempts. A wait taken
+// from a Retry-After header is not capped.
+func WithMaxWait(d time.Duration) RetryOption {
+	return func(p *RetryPolicy) {
+		p.MaxWait = d
+	}
+}
+
+// WithOnRetry registers a hook invoked before every retried attempt, mainly
+// useful for logging/metrics.
+func WithOnRetry(fn func(attempt int, resp *http.Response, err error)) RetryOption {
+	return func(p *RetryPolicy) {
+		p.OnRetry = fn
+	}
+}
+
+// WithRetryIf overrides the condition under which an attempt is retried.
+func WithRetryIf(cond RetryCondition) RetryOption {
+	return func(p *RetryPolicy) {
+		p.Condition = cond
+	}
+}
+
+func newRetryPolicy(count int, opts ...RetryOption) *RetryPolicy {
+	policy := &RetryPolicy{
+		Count:    count,
+		BaseWait: defaultRetryBaseWait,
+		MaxWait:  defaultRetryMaxWait,
+	}
+
+	for _, opt := range opts {
+		opt(policy)
+	}
+
+	return policy
+}
+
+// defaultRetryCondition only retries idempotent methods (GET/HEAD/PUT/DELETE)
+// or requests whose body is empty/replayable, and only for transport errors
+// or responses that signal the server is temporarily unavailable.
+func defaultRetryCondition(method string, nonReplayableBody bool) RetryCondition {
+	idempotent := isIdempotentMethod(method)
+
+	return func(resp *http.Response, err error) bool {
+		if !idempotent && nonReplayableBody {
+			return false
+		}
+
+		if err != nil {
+			return true
+		}
+
+		if resp == nil {
+			return false
+		}
+
+		if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable {
+			return true
+		}
+
+		return resp.StatusCode >= 500
+	}
+}
+
+func isIdempotentMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// retryWait computes how long to wait before the next attempt, honoring a
+// Retry-After header on a 429/503 response and otherwise applying
+// exponential backoff with full jitter.
+func retryWait(policy *RetryPolicy, attempt int, resp *http.Response) time.Duration {
+	if resp != nil && (resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable) {
+		if d, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			return d
+		}
+	}
+
+	base := policy.BaseWait
+	if base <= 0 {
+		base = defaultRetryBaseWait
+	}
+
+	maxWait := policy.MaxWait
+	if maxWait <= 0 {
+		maxWait = defaultRetryMaxWait
+	}
+
+	backoff := time.Duration(float64(base) * math.Pow(2, float64(attempt)))
+	if backoff <= 0 || backoff > maxWait {
+		backoff = maxWait
+	}
+
+	return time.Duration(rand.Int63n(int64(backoff) + 1))
+}
+
+// parseRetryAfter parses the Retry-After header, which per RFC 7231 is
+// either a number of seconds or an HTTP-date.
+func parseRetryAfter(v string) (time.Duration, bool) {
+	if v == "" {
+		return 0, false
+	}
+
+	if secs, err := strconv.Atoi(v); err == nil {
+		if secs < 0 {
+			return 0, false
+		}
+
+		return time.Duration(secs) * time.Second, true
+	}
+
+	if t, err := http.ParseTime(v); err == nil {
+		d := time.Until(t)
+		if d < 0 {
+			d = 0
+		}
+
+		return d, true
+	}
+
+	return 0, false
+}
@@ -0,0 +1,98 @@
+package go_http_client
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// TestSubmitRetriesOnServerError exercises the retry subsystem end-to-end:
+// the first two attempts 500, the third succeeds, and the request should
+// transparently return the eventual success.
+func TestSubmitRetriesOnServerError(t *testing.T) {
+	var attempts int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts < 3 {
+			w.WriteHeader(http.StatusInternalServerError)
+
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client, err := HttpClientFor(server.URL)
+	if err != nil {
+		t.Fatalf("HttpClientFor: %v", err)
+	}
+
+	resp, err := client.NewHttpRequest().
+		Retry(3, WithBaseWait(time.Millisecond), WithMaxWait(5*time.Millisecond)).
+		Get("/")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+
+	if !resp.IsSuccess() {
+		t.Fatalf("expected success, got status %d", resp.StatusCode())
+	}
+
+	if attempts != 3 {
+		t.Fatalf("expected 3 attempts, got %d", attempts)
+	}
+}
+
+// TestSubmitHonorsRetryAfter checks that a 503 with a numeric Retry-After
+// header is retried rather than treated as a final failure.
+func TestSubmitHonorsRetryAfter(t *testing.T) {
+	var attempts int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts < 2 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusServiceUnavailable)
+
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client, err := HttpClientFor(server.URL)
+	if err != nil {
+		t.Fatalf("HttpClientFor: %v", err)
+	}
+
+	resp, err := client.NewHttpRequest().Retry(2).Get("/")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+
+	if !resp.IsSuccess() || attempts != 2 {
+		t.Fatalf("expected success after 2 attempts, got status %d after %d attempts", resp.StatusCode(), attempts)
+	}
+}
+
+// TestRetryIfDoesNotMutateSharedPolicy is a regression test: NewHttpRequest
+// seeds HttpRequest's retry policy straight from the client's shared
+// *RetryPolicy, so RetryIf must clone it before overriding Condition rather
+// than mutating the client-wide policy that every other request sees.
+func TestRetryIfDoesNotMutateSharedPolicy(t *testing.T) {
+	client, err := HttpClientFor("http://example.invalid")
+	if err != nil {
+		t.Fatalf("HttpClientFor: %v", err)
+	}
+	client.RetryPolicy(newRetryPolicy(3))
+
+	client.NewHttpRequest().RetryIf(func(resp *http.Response, err error) bool {
+		return true
+	})
+
+	if client.GetRetryPolicy().Condition != nil {
+		t.Fatal("RetryIf mutated the client's shared RetryPolicy")
+	}
+}